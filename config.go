@@ -0,0 +1,85 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"gopkg.in/yaml.v3"
+)
+
+const configFileName = "config.yml"
+
+// ColorTheme es el tema de color elegido para la interfaz Fyne.
+type ColorTheme string
+
+const (
+	ColorThemeDark  ColorTheme = "dark"
+	ColorThemeLight ColorTheme = "light"
+)
+
+// Config son las preferencias del usuario, persistidas en config.yml entre sesiones.
+type Config struct {
+	Heuristic    string     `yaml:"heuristic"`
+	Algorithm    string     `yaml:"algorithm"`
+	ShuffleSteps int        `yaml:"shuffleSteps"`
+	FrameDelayMs int        `yaml:"frameDelayMs"`
+	Theme        ColorTheme `yaml:"theme"`
+	MaxExpand    int        `yaml:"maxExpand"`
+}
+
+// defaultConfig son los valores usados la primera vez que se arranca la app.
+func defaultConfig() Config {
+	return Config{
+		Heuristic:    heuristicDisplayName[heuristicManhattan],
+		Algorithm:    algorithmDisplayName[algorithmAStar],
+		ShuffleSteps: defaultShuffleSteps,
+		FrameDelayMs: animateFrameMs,
+		Theme:        ColorThemeDark,
+		MaxExpand:    defaultMaxExpand,
+	}
+}
+
+func configPath() (string, error) {
+	dir, err := appConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, configFileName), nil
+}
+
+// LoadConfig lee config.yml bajo el directorio de configuración del usuario;
+// si no existe lo crea con los valores por defecto.
+func LoadConfig() (Config, error) {
+	path, err := configPath()
+	if err != nil {
+		return defaultConfig(), err
+	}
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		cfg := defaultConfig()
+		return cfg, SaveConfig(cfg)
+	}
+	if err != nil {
+		return defaultConfig(), err
+	}
+	var cfg Config
+	if err := yaml.Unmarshal(data, &cfg); err != nil {
+		return defaultConfig(), fmt.Errorf("config inválida en %s: %w", path, err)
+	}
+	return cfg, nil
+}
+
+// SaveConfig escribe las preferencias actuales en config.yml.
+func SaveConfig(cfg Config) error {
+	path, err := configPath()
+	if err != nil {
+		return err
+	}
+	data, err := yaml.Marshal(cfg)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0o644)
+}
@@ -0,0 +1,32 @@
+package main
+
+// Action representa una entrada de usuario traducida a una orden del juego,
+// independiente de si proviene de un clic en Fyne o de una tecla en la terminal.
+type Action int
+
+const (
+	ActionNone Action = iota
+	ActionMoveUp
+	ActionMoveDown
+	ActionMoveLeft
+	ActionMoveRight
+	ActionShuffle
+	ActionSolve
+	ActionStep
+	ActionReset
+	ActionQuit
+)
+
+// Frontend desacopla el dibujado del tablero y la entrada del usuario de los
+// detalles de Fyne o de la terminal; cada implementación resuelve sus propias
+// Action internamente y solo expone lo que main necesita para arrancarla.
+// Seleccionar una u otra es cosa del flag -ui de main, no del resto del
+// programa.
+type Frontend interface {
+	// Paint dibuja el estado recibido.
+	Paint(State)
+	// SetStatus actualiza el mensaje de estado visible para el usuario.
+	SetStatus(string)
+	// Run arranca el bucle principal del frontend; bloquea hasta salir.
+	Run() error
+}
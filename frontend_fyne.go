@@ -0,0 +1,875 @@
+package main
+
+import (
+	"fmt"
+	"image/color"
+	"math"
+	"math/rand"
+	"path/filepath"
+	"strconv"
+	"time"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/app"
+	"fyne.io/fyne/v2/canvas"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/layout"
+	"fyne.io/fyne/v2/theme"
+	"fyne.io/fyne/v2/widget"
+)
+
+const (
+	windowTitle        = "N-Puzzle (Go + Fyne)"
+	windowWidth        = 560
+	windowHeight       = 780
+	labelTitle         = "N-Puzzle • A* con heurística seleccionable"
+	labelHeuristic     = "Heurística:"
+	labelAlgorithm     = "Algoritmo:"
+	labelBoardSize     = "Tamaño:"
+	labelTheme         = "Tema:"
+	labelMaxExpand     = "Límite de nodos (0=sin límite):"
+	statusReadyMessage = "Listo."
+	statusResetMessage = "Estado reiniciado (meta)."
+	statusAlreadyFinal = "Ya estás en el estado final."
+	msgMixedFmt        = "Mezclado con %d pasos válidos."
+	msgSolvedFmt       = "Solución en %d pasos • Nodos expandidos: %d"
+	msgStepFmt         = "Paso %d / %d"
+
+	buttonInitText    = "Iniciar"
+	buttonShuffleText = "Mezclar"
+	buttonSolveText   = "Resolver"
+	buttonStepText    = "Paso"
+
+	// Tiles
+	tileSize         = 54
+	tileCornerRadius = 10
+	tileFontSize     = 20
+
+	// Mezcla
+	shuffleStepsMin     = 0
+	shuffleStepsMax     = 200
+	defaultShuffleSteps = 30
+
+	// Animación
+	animateFrameMs = 140
+
+	// Biblioteca de puzzles
+	libraryGenerateBatch = 5
+	labelDifficulty      = "Dificultad:"
+	buttonLoadPuzzleText = "Cargar puzzle"
+	buttonDailyText      = "Puzzle del día"
+)
+
+// Colores en (hex)
+const (
+	colorBgDarkHex      = "#0f172a"
+	colorBgLightHex     = "#f8fafc"
+	colorFgDarkHex      = "#e5e7eb"
+	colorFgLightHex     = "#0f172a"
+	colorPrimaryHex     = "#22c55e"
+	colorTileHex        = "#334155"
+	colorTileBlankHex   = "#1f2937"
+	colorPlaceholderHex = "#9ca3af"
+)
+
+// boardSizeOptions enumera los tamaños jugables, del 8-puzzle clásico al 35-puzzle.
+var boardSizeOptions = []int{3, 4, 5, 6}
+
+func boardSizeLabel(n int) string {
+	return fmt.Sprintf("%d×%d (%d-puzzle)", n, n, n*n-1)
+}
+
+type sleekTheme struct{}
+
+func (sleekTheme) Color(name fyne.ThemeColorName, variant fyne.ThemeVariant) color.Color {
+	switch name {
+	case theme.ColorNameBackground:
+		if variant == theme.VariantLight {
+			return mustHex(colorBgLightHex)
+		}
+		return mustHex(colorBgDarkHex)
+	case theme.ColorNameForeground:
+		if variant == theme.VariantLight {
+			return mustHex(colorFgLightHex)
+		}
+		return mustHex(colorFgDarkHex)
+	case theme.ColorNamePrimary:
+		return mustHex(colorPrimaryHex)
+	case theme.ColorNameButton:
+		return mustHex(colorTileHex)
+	case theme.ColorNameInputBackground:
+		if variant == theme.VariantLight {
+			return color.White
+		}
+		return mustHex(colorTileBlankHex)
+	case theme.ColorNamePlaceHolder:
+		return mustHex(colorPlaceholderHex)
+	default:
+		return theme.DefaultTheme().Color(name, variant)
+	}
+}
+
+func (sleekTheme) Font(style fyne.TextStyle) fyne.Resource { return theme.DefaultTheme().Font(style) }
+func (sleekTheme) Icon(name fyne.ThemeIconName) fyne.Resource {
+	return theme.DefaultTheme().Icon(name)
+}
+func (sleekTheme) Size(name fyne.ThemeSizeName) float32 { return theme.DefaultTheme().Size(name) }
+
+// forcedVariantTheme envuelve sleekTheme para ignorar el variant del sistema
+// operativo y aplicar siempre el tema (oscuro/claro) elegido por el usuario.
+type forcedVariantTheme struct {
+	base    fyne.Theme
+	variant fyne.ThemeVariant
+}
+
+func (f forcedVariantTheme) Color(name fyne.ThemeColorName, _ fyne.ThemeVariant) color.Color {
+	return f.base.Color(name, f.variant)
+}
+func (f forcedVariantTheme) Font(style fyne.TextStyle) fyne.Resource { return f.base.Font(style) }
+func (f forcedVariantTheme) Icon(name fyne.ThemeIconName) fyne.Resource {
+	return f.base.Icon(name)
+}
+func (f forcedVariantTheme) Size(name fyne.ThemeSizeName) float32 { return f.base.Size(name) }
+
+func variantForColorTheme(t ColorTheme) fyne.ThemeVariant {
+	if t == ColorThemeLight {
+		return theme.VariantLight
+	}
+	return theme.VariantDark
+}
+
+// Helpers de color
+func mustHex(s string) color.Color {
+	c, err := parseHexColor(s)
+	if err != nil {
+		return color.White
+	}
+	return c
+}
+
+func parseHexColor(s string) (color.NRGBA, error) {
+	if len(s) != 7 || s[0] != '#' {
+		return color.NRGBA{}, fmt.Errorf("invalid hex: %s", s)
+	}
+	var rr, gg, bb uint8
+	if _, err := fmt.Sscanf(s, "#%02x%02x%02x", &rr, &gg, &bb); err != nil {
+		return color.NRGBA{}, err
+	}
+	return color.NRGBA{R: rr, G: gg, B: bb, A: 255}, nil
+}
+
+// Tap area transparentes
+type tapArea struct {
+	widget.BaseWidget
+	onTap func()
+}
+
+func newTapArea(onTap func()) *tapArea {
+	t := &tapArea{onTap: onTap}
+	t.ExtendBaseWidget(t)
+	return t
+}
+func (t *tapArea) CreateRenderer() fyne.WidgetRenderer {
+	rect := canvas.NewRectangle(color.NRGBA{0, 0, 0, 0})
+	return widget.NewSimpleRenderer(rect)
+}
+func (t *tapArea) Tapped(*fyne.PointEvent) {
+	if t.onTap != nil {
+		t.onTap()
+	}
+}
+func (t *tapArea) TappedSecondary(*fyne.PointEvent) {}
+func (t *tapArea) MinSize() fyne.Size               { return fyne.NewSize(tileSize, tileSize) }
+
+// Componente Tile
+type tile struct {
+	background *canvas.Rectangle
+	label      *canvas.Text
+	wrapper    *fyne.Container
+}
+
+func newTile(onTap func()) *tile {
+	bg := canvas.NewRectangle(mustHex(colorTileHex))
+	bg.CornerRadius = tileCornerRadius
+	lbl := canvas.NewText("", mustHex(colorFgDarkHex))
+	lbl.TextStyle = fyne.TextStyle{Bold: true}
+	lbl.TextSize = tileFontSize
+
+	center := container.NewCenter(lbl)
+	tapper := newTapArea(onTap)
+	wrap := container.NewMax(bg, center, tapper)
+
+	return &tile{
+		background: bg,
+		label:      lbl,
+		wrapper:    wrap,
+	}
+}
+
+func (t *tile) setNumber(n int) {
+	if n == BlankTile {
+		t.label.Text = ""
+		t.background.FillColor = mustHex(colorTileBlankHex)
+	} else {
+		t.label.Text = strconv.Itoa(n)
+		t.background.FillColor = mustHex(colorTileHex)
+	}
+	t.label.Refresh()
+	t.background.Refresh()
+}
+
+// puzzleUI es la implementación de Frontend sobre Fyne.
+type puzzleUI struct {
+	app               fyne.App
+	config            Config
+	themeSelect       *widget.Select
+	window            fyne.Window
+	boardSize         int
+	gridContainer     *fyne.Container
+	tiles             []*tile
+	currentState      State
+	solutionPath      []State
+	stepIndex         int
+	heuristicSelect   *widget.Select
+	algorithmSelect   *widget.Select
+	sizeSelect        *widget.Select
+	shuffleSlider     *widget.Slider
+	shuffleValueLabel *widget.Label
+	statusLabel       *widget.Label
+
+	// animación
+	isAnimating bool
+	animCancel  chan struct{}
+
+	maxExpandEntry *widget.Entry
+
+	// refs para deshabilitar
+	btnInit    *widget.Button
+	btnShuffle *widget.Button
+	btnSolve   *widget.Button
+	btnStep    *widget.Button
+
+	// biblioteca de puzzles y progreso del usuario
+	library          *Library
+	progress         *Progress
+	libraryPath      string
+	progressPath     string
+	difficultySelect *widget.Select
+	btnLoadPuzzle    *widget.Button
+	btnDailyPuzzle   *widget.Button
+	statsLabel       *widget.Label
+	currentEntryID   string
+	currentOptimal   int
+	attemptStart     time.Time
+	manualMoveCount  int
+}
+
+// newFyneFrontend construye la ventana y todos sus controles, lista para Run().
+func newFyneFrontend() *puzzleUI {
+	cfg, cfgErr := LoadConfig()
+
+	a := app.New()
+	a.Settings().SetTheme(forcedVariantTheme{base: sleekTheme{}, variant: variantForColorTheme(cfg.Theme)})
+
+	w := a.NewWindow(windowTitle)
+	w.Resize(fyne.NewSize(windowWidth, windowHeight))
+
+	ui := &puzzleUI{
+		app:          a,
+		config:       cfg,
+		window:       w,
+		boardSize:    DefaultGridSize,
+		currentState: Goal(DefaultGridSize),
+		statusLabel:  widget.NewLabel(statusReadyMessage),
+	}
+
+	if cfgErr != nil {
+		dialog.ShowError(fmt.Errorf("no se pudo cargar config.yml, usando valores por defecto: %w", cfgErr), w)
+	}
+
+	// Heurística
+	heuristicOptions := []string{
+		heuristicDisplayName[heuristicManhattan],
+		heuristicDisplayName[heuristicMisplaced],
+		heuristicDisplayName[heuristicPatternDB],
+	}
+	ui.heuristicSelect = widget.NewSelect(heuristicOptions, func(string) { ui.saveConfig() })
+	ui.heuristicSelect.Selected = cfg.Heuristic
+
+	// Algoritmo
+	algorithmOptions := []string{
+		algorithmDisplayName[algorithmAStar],
+		algorithmDisplayName[algorithmIDAStar],
+	}
+	ui.algorithmSelect = widget.NewSelect(algorithmOptions, func(string) { ui.saveConfig() })
+	ui.algorithmSelect.Selected = cfg.Algorithm
+
+	// Tema de color
+	themeOptions := []string{string(ColorThemeDark), string(ColorThemeLight)}
+	ui.themeSelect = widget.NewSelect(themeOptions, func(selected string) {
+		ui.config.Theme = ColorTheme(selected)
+		ui.app.Settings().SetTheme(forcedVariantTheme{base: sleekTheme{}, variant: variantForColorTheme(ui.config.Theme)})
+		ui.saveConfig()
+	})
+	ui.themeSelect.Selected = string(cfg.Theme)
+
+	// Límite de nodos expandidos (0 = sin límite)
+	ui.maxExpandEntry = widget.NewEntry()
+	ui.maxExpandEntry.SetText(strconv.Itoa(cfg.MaxExpand))
+	ui.maxExpandEntry.OnChanged = func(v string) {
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 0 {
+			return
+		}
+		ui.config.MaxExpand = n
+		ui.saveConfig()
+	}
+
+	// Tamaño de tablero
+	sizeOptions := make([]string, len(boardSizeOptions))
+	for i, n := range boardSizeOptions {
+		sizeOptions[i] = boardSizeLabel(n)
+	}
+	ui.sizeSelect = widget.NewSelect(sizeOptions, func(string) { ui.changeBoardSize() })
+	ui.sizeSelect.Selected = boardSizeLabel(DefaultGridSize)
+
+	// Biblioteca de puzzles
+	ui.library = &Library{}
+	ui.progress = &Progress{Stats: map[string]UserStats{}}
+	if dir, err := appConfigDir(); err == nil {
+		ui.libraryPath = filepath.Join(dir, "library.json")
+		ui.progressPath = filepath.Join(dir, "progress.json")
+		if lib, err := LoadLibrary(ui.libraryPath); err == nil {
+			ui.library = lib
+		}
+		if prog, err := LoadProgress(ui.progressPath); err == nil {
+			ui.progress = prog
+		}
+	}
+	difficultyOptions := []string{
+		difficultyDisplayName[DifficultyEasy],
+		difficultyDisplayName[DifficultyMedium],
+		difficultyDisplayName[DifficultyHard],
+		difficultyDisplayName[DifficultyExpert],
+	}
+	ui.difficultySelect = widget.NewSelect(difficultyOptions, func(string) {})
+	ui.difficultySelect.SetSelected(difficultyOptions[0])
+	ui.btnLoadPuzzle = widget.NewButton(buttonLoadPuzzleText, func() { ui.loadFromLibrary() })
+	ui.btnDailyPuzzle = widget.NewButton(buttonDailyText, func() { ui.loadDailyPuzzle() })
+	ui.statsLabel = widget.NewLabel("")
+
+	// Slider mezcla
+	ui.shuffleSlider = widget.NewSlider(shuffleStepsMin, shuffleStepsMax)
+	ui.shuffleSlider.Step = 1
+	ui.shuffleSlider.Value = float64(cfg.ShuffleSteps)
+	ui.shuffleValueLabel = widget.NewLabel(strconv.Itoa(cfg.ShuffleSteps))
+	ui.shuffleSlider.OnChanged = func(v float64) {
+		ui.shuffleValueLabel.SetText(strconv.Itoa(int(math.Round(v))))
+	}
+	ui.shuffleSlider.OnChangeEnded = func(v float64) {
+		ui.config.ShuffleSteps = int(math.Round(v))
+		ui.saveConfig()
+	}
+
+	// Grid n×n
+	grid := ui.buildGrid(ui.boardSize)
+
+	// Toolbar
+	toolbar := widget.NewToolbar(
+		widget.NewToolbarAction(theme.HomeIcon(), func() { ui.dispatch(ActionReset) }),
+		widget.NewToolbarAction(theme.ViewRefreshIcon(), func() { ui.dispatch(ActionShuffle) }),
+		widget.NewToolbarAction(theme.ConfirmIcon(), func() { ui.dispatch(ActionSolve) }),
+		widget.NewToolbarAction(theme.NavigateNextIcon(), func() { ui.dispatch(ActionStep) }),
+	)
+
+	// Controles
+	ui.btnInit = widget.NewButton(buttonInitText, func() { ui.dispatch(ActionReset) })
+	ui.btnShuffle = widget.NewButton(buttonShuffleText, func() { ui.dispatch(ActionShuffle) })
+	ui.btnSolve = widget.NewButton(buttonSolveText, func() { ui.dispatch(ActionSolve) })
+	ui.btnStep = widget.NewButton(buttonStepText, func() { ui.dispatch(ActionStep) })
+
+	controls := widget.NewCard("Controles", "",
+		container.NewVBox(
+			container.NewGridWithColumns(2,
+				widget.NewLabel(labelBoardSize),
+				ui.sizeSelect,
+			),
+			container.NewGridWithColumns(2,
+				widget.NewLabel(labelHeuristic),
+				ui.heuristicSelect,
+			),
+			container.NewGridWithColumns(2,
+				widget.NewLabel(labelAlgorithm),
+				ui.algorithmSelect,
+			),
+			container.NewGridWithColumns(2,
+				widget.NewLabel(labelTheme),
+				ui.themeSelect,
+			),
+			container.NewGridWithColumns(2,
+				widget.NewLabel(labelMaxExpand),
+				ui.maxExpandEntry,
+			),
+			widget.NewSeparator(),
+			widget.NewLabel("Pasos a mezclar:"),
+			container.NewBorder(nil, nil, nil, ui.shuffleValueLabel, ui.shuffleSlider),
+			container.NewHBox(ui.btnInit, ui.btnShuffle, ui.btnSolve, ui.btnStep),
+		),
+	)
+
+	library := widget.NewCard("Biblioteca", "",
+		container.NewVBox(
+			container.NewGridWithColumns(2,
+				widget.NewLabel(labelDifficulty),
+				ui.difficultySelect,
+			),
+			container.NewHBox(ui.btnLoadPuzzle, ui.btnDailyPuzzle),
+			ui.statsLabel,
+		),
+	)
+
+	// Título
+	titleText := canvas.NewText(labelTitle, mustHex(colorFgDarkHex))
+	titleText.TextStyle = fyne.TextStyle{Bold: true}
+	titleText.Alignment = fyne.TextAlignCenter
+	titleBar := container.NewPadded(container.NewCenter(titleText))
+
+	root := container.NewBorder(
+		container.NewVBox(titleBar, toolbar),
+		ui.statusLabel,
+		nil,
+		nil,
+		container.NewVBox(grid, controls, library),
+	)
+
+	w.SetContent(container.NewPadded(root))
+	w.Canvas().SetOnTypedKey(func(ev *fyne.KeyEvent) {
+		switch ev.Name {
+		case fyne.KeyUp:
+			ui.dispatch(ActionMoveUp)
+		case fyne.KeyDown:
+			ui.dispatch(ActionMoveDown)
+		case fyne.KeyLeft:
+			ui.dispatch(ActionMoveLeft)
+		case fyne.KeyRight:
+			ui.dispatch(ActionMoveRight)
+		}
+	})
+	ui.Paint(ui.currentState)
+	w.SetCloseIntercept(func() {
+		ui.saveConfig()
+		w.Close()
+	})
+	return ui
+}
+
+// saveConfig vuelca la selección actual de controles a Config y la persiste.
+func (ui *puzzleUI) saveConfig() {
+	ui.config.Heuristic = ui.heuristicSelect.Selected
+	ui.config.Algorithm = ui.algorithmSelect.Selected
+	ui.config.Theme = ColorTheme(ui.themeSelect.Selected)
+	ui.config.ShuffleSteps = int(math.Round(ui.shuffleSlider.Value))
+	if n, err := strconv.Atoi(ui.maxExpandEntry.Text); err == nil && n >= 0 {
+		ui.config.MaxExpand = n
+	}
+	if err := SaveConfig(ui.config); err != nil {
+		dialog.ShowError(fmt.Errorf("no se pudo guardar config.yml: %w", err), ui.window)
+	}
+}
+
+// Frontend: Run muestra la ventana y bloquea hasta que se cierra.
+func (ui *puzzleUI) Run() error {
+	ui.window.ShowAndRun()
+	return nil
+}
+
+// Frontend: SetStatus delega en la etiqueta de estado.
+func (ui *puzzleUI) SetStatus(msg string) { ui.statusLabel.SetText(msg) }
+
+func (ui *puzzleUI) dispatch(a Action) {
+	switch a {
+	case ActionReset:
+		ui.reset()
+	case ActionShuffle:
+		ui.shuffle()
+	case ActionSolve:
+		ui.solveAnimated()
+	case ActionStep:
+		ui.step()
+	case ActionMoveUp, ActionMoveDown, ActionMoveLeft, ActionMoveRight:
+		ui.manualMove(a)
+	}
+}
+
+// manualMove interpreta la flecha como la dirección en la que se mueve el
+// hueco, igual que tuiFrontend.manualMove; a diferencia de ActionStep (que
+// avanza la solución calculada), esto es un movimiento del usuario, así que
+// cuenta como resuelto sin ayuda si llega a la meta.
+func (ui *puzzleUI) manualMove(a Action) {
+	ui.stopAnimation()
+	n := ui.currentState.Size()
+	blank := ui.currentState.blankIndex()
+	row, col := blank/n, blank%n
+
+	targetRow, targetCol := row, col
+	switch a {
+	case ActionMoveUp:
+		targetRow--
+	case ActionMoveDown:
+		targetRow++
+	case ActionMoveLeft:
+		targetCol--
+	case ActionMoveRight:
+		targetCol++
+	}
+	if targetRow < 0 || targetRow >= n || targetCol < 0 || targetCol >= n {
+		return
+	}
+	targetIdx := targetRow*n + targetCol
+	for _, nb := range ui.currentState.Neighbors() {
+		if nb.blankIndex() != targetIdx {
+			continue
+		}
+		ui.currentState = nb
+		ui.solutionPath = nil
+		ui.stepIndex = 0
+		ui.manualMoveCount++
+		ui.Paint(ui.currentState)
+		if ui.currentState.Equal(Goal(n)) {
+			ui.SetStatus(fmt.Sprintf(msgSolvedFmt, ui.manualMoveCount, 0))
+			ui.recordIfEntry(ui.manualMoveCount, false)
+		} else {
+			ui.SetStatus(statusReadyMessage)
+		}
+		return
+	}
+}
+
+// Acciones
+func (ui *puzzleUI) reset() {
+	ui.stopAnimation()
+	ui.currentState = Goal(ui.boardSize)
+	ui.currentEntryID = ""
+	ui.solutionPath = nil
+	ui.stepIndex = 0
+	ui.manualMoveCount = 0
+	ui.Paint(ui.currentState)
+	ui.SetStatus(statusResetMessage)
+}
+
+func (ui *puzzleUI) shuffle() {
+	ui.stopAnimation()
+	steps := int(math.Round(ui.shuffleSlider.Value))
+	state, err := ShuffleFromGoal(ui.boardSize, steps)
+	if err != nil {
+		dialog.ShowError(err, ui.window)
+		return
+	}
+	ui.currentState = state
+	ui.currentEntryID = ""
+	ui.solutionPath = nil
+	ui.stepIndex = 0
+	ui.manualMoveCount = 0
+	ui.Paint(ui.currentState)
+	ui.SetStatus(fmt.Sprintf(msgMixedFmt, steps))
+}
+
+func (ui *puzzleUI) solve() (SearchResult, error) {
+	kind := ui.selectedHeuristic()
+	if ui.selectedAlgorithm() == algorithmIDAStar {
+		return IDAStar(ui.currentState, kind)
+	}
+	return Puzzle(ui.currentState, kind, ui.config.MaxExpand)
+}
+
+func (ui *puzzleUI) solveAnimated() {
+	if ui.currentState.Equal(Goal(ui.boardSize)) {
+		ui.SetStatus(statusAlreadyFinal)
+		return
+	}
+	result, err := ui.solve()
+	if err != nil {
+		dialog.ShowError(err, ui.window)
+		return
+	}
+	if !result.found || len(result.path) == 0 {
+		dialog.ShowError(errNoSolution, ui.window)
+		return
+	}
+
+	ui.stopAnimation()
+	ui.solutionPath = result.path
+	ui.stepIndex = 0
+	ui.disableControls(true)
+	ui.isAnimating = true
+	ui.animCancel = make(chan struct{})
+
+	frameDelay := ui.config.FrameDelayMs
+	go func(path []State, expanded int) {
+		ticker := time.NewTicker(time.Millisecond * time.Duration(frameDelay))
+		defer ticker.Stop()
+
+		total := len(path) - 1
+		for ui.stepIndex < len(path) {
+			select {
+			case <-ui.animCancel:
+				return
+			case <-ticker.C:
+				state := path[ui.stepIndex]
+
+				ui.Paint(state)
+				ui.SetStatus(fmt.Sprintf(msgStepFmt, ui.stepIndex, total))
+				ui.stepIndex++
+			}
+		}
+		ui.SetStatus(fmt.Sprintf(msgSolvedFmt, total, expanded))
+		ui.recordIfEntry(total, true)
+		ui.disableControls(false)
+		ui.isAnimating = false
+	}(result.path, result.expanded)
+}
+
+func (ui *puzzleUI) step() {
+	if ui.isAnimating {
+		ui.stopAnimation()
+	}
+	if len(ui.solutionPath) == 0 {
+		result, err := ui.solve()
+		if err != nil {
+			dialog.ShowError(err, ui.window)
+			return
+		}
+		if !result.found || len(result.path) == 0 {
+			dialog.ShowError(errNoSolution, ui.window)
+			return
+		}
+		ui.solutionPath = result.path
+		ui.stepIndex = 0
+	}
+	if ui.stepIndex >= len(ui.solutionPath) {
+		ui.SetStatus(statusAlreadyFinal)
+		return
+	}
+	nextState := ui.solutionPath[ui.stepIndex]
+	ui.Paint(nextState)
+	ui.SetStatus(fmt.Sprintf(msgStepFmt, ui.stepIndex, len(ui.solutionPath)-1))
+	ui.stepIndex++
+	if ui.stepIndex == len(ui.solutionPath) {
+		ui.recordIfEntry(len(ui.solutionPath)-1, true)
+	}
+}
+
+// changeBoardSize reconstruye la cuadrícula cuando el usuario elige otro tamaño.
+func (ui *puzzleUI) changeBoardSize() {
+	for _, n := range boardSizeOptions {
+		if boardSizeLabel(n) == ui.sizeSelect.Selected {
+			ui.stopAnimation()
+			ui.boardSize = n
+			ui.currentState = Goal(n)
+			ui.currentEntryID = ""
+			ui.solutionPath = nil
+			ui.stepIndex = 0
+			ui.manualMoveCount = 0
+			ui.rebuildGrid(n)
+			ui.Paint(ui.currentState)
+			ui.SetStatus(statusResetMessage)
+			return
+		}
+	}
+}
+
+// Animación helperss
+func (ui *puzzleUI) stopAnimation() {
+	if ui.isAnimating {
+		if ui.animCancel != nil {
+			close(ui.animCancel)
+		}
+		ui.animCancel = nil
+		ui.isAnimating = false
+		ui.disableControls(false)
+	}
+}
+
+func (ui *puzzleUI) disableControls(disable bool) {
+	if disable {
+		ui.heuristicSelect.Disable()
+		ui.algorithmSelect.Disable()
+		ui.themeSelect.Disable()
+		ui.sizeSelect.Disable()
+		ui.difficultySelect.Disable()
+		ui.btnInit.Disable()
+		ui.btnShuffle.Disable()
+		ui.btnSolve.Disable()
+		ui.btnStep.Disable()
+		ui.btnLoadPuzzle.Disable()
+		ui.btnDailyPuzzle.Disable()
+	} else {
+		ui.heuristicSelect.Enable()
+		ui.algorithmSelect.Enable()
+		ui.themeSelect.Enable()
+		ui.sizeSelect.Enable()
+		ui.difficultySelect.Enable()
+		ui.btnInit.Enable()
+		ui.btnShuffle.Enable()
+		ui.btnSolve.Enable()
+		ui.btnStep.Enable()
+		ui.btnLoadPuzzle.Enable()
+		ui.btnDailyPuzzle.Enable()
+	}
+}
+
+// Utilidades UI
+func (ui *puzzleUI) buildGrid(n int) *fyne.Container {
+	ui.tiles = make([]*tile, n*n)
+	objects := make([]fyne.CanvasObject, 0, n*n)
+	for i := 0; i < n*n; i++ {
+		t := newTile(func() { ui.dispatch(ActionStep) }) // clic = avanzar paso
+		ui.tiles[i] = t
+		objects = append(objects, t.wrapper)
+	}
+	ui.gridContainer = container.New(layout.NewGridLayoutWithColumns(n), objects...)
+	return ui.gridContainer
+}
+
+// rebuildGrid reemplaza los tiles en el contenedor existente sin recrear el layout de la ventana.
+func (ui *puzzleUI) rebuildGrid(n int) {
+	ui.tiles = make([]*tile, n*n)
+	objects := make([]fyne.CanvasObject, 0, n*n)
+	for i := 0; i < n*n; i++ {
+		t := newTile(func() { ui.dispatch(ActionStep) })
+		ui.tiles[i] = t
+		objects = append(objects, t.wrapper)
+	}
+	ui.gridContainer.Layout = layout.NewGridLayoutWithColumns(n)
+	ui.gridContainer.Objects = objects
+	ui.gridContainer.Refresh()
+}
+
+// Frontend: Paint pinta el estado n×n actual.
+func (ui *puzzleUI) Paint(state State) {
+	n := state.Size()
+	for i := 0; i < n*n; i++ {
+		ui.tiles[i].setNumber(state.At(i))
+	}
+}
+
+func (ui *puzzleUI) selectedHeuristic() Heuristic {
+	switch ui.heuristicSelect.Selected {
+	case heuristicDisplayName[heuristicMisplaced]:
+		return heuristicMisplaced
+	case heuristicDisplayName[heuristicPatternDB]:
+		return heuristicPatternDB
+	default:
+		return heuristicManhattan
+	}
+}
+
+func (ui *puzzleUI) selectedAlgorithm() Algorithm {
+	switch ui.algorithmSelect.Selected {
+	case algorithmDisplayName[algorithmIDAStar]:
+		return algorithmIDAStar
+	default:
+		return algorithmAStar
+	}
+}
+
+func (ui *puzzleUI) selectedDifficulty() Difficulty {
+	for d, name := range difficultyDisplayName {
+		if name == ui.difficultySelect.Selected {
+			return d
+		}
+	}
+	return DifficultyEasy
+}
+
+// loadFromLibrary carga un puzzle al azar de la dificultad elegida,
+// generando un lote nuevo con A* si la biblioteca aún no tiene ninguno.
+func (ui *puzzleUI) loadFromLibrary() {
+	bucket := ui.selectedDifficulty()
+	entries := ui.library.ByDifficulty(bucket)
+	if len(entries) == 0 {
+		if err := ui.library.Generate(ui.boardSize, libraryGenerateBatch, bucket, heuristicManhattan); err != nil && len(ui.library.Entries) == 0 {
+			dialog.ShowError(err, ui.window)
+			return
+		}
+		ui.saveLibrary()
+		entries = ui.library.ByDifficulty(bucket)
+	}
+	if len(entries) == 0 {
+		dialog.ShowError(fmt.Errorf("no hay puzzles de dificultad %s", difficultyDisplayName[bucket]), ui.window)
+		return
+	}
+	ui.loadEntry(entries[rand.Intn(len(entries))])
+}
+
+// loadDailyPuzzle carga el tablero del día, determinado por la fecha actual.
+func (ui *puzzleUI) loadDailyPuzzle() {
+	if len(ui.library.Entries) == 0 {
+		if err := ui.library.Generate(ui.boardSize, libraryGenerateBatch, DifficultyMedium, heuristicManhattan); err != nil && len(ui.library.Entries) == 0 {
+			dialog.ShowError(err, ui.window)
+			return
+		}
+		ui.saveLibrary()
+	}
+	entry, err := ui.library.DailyPuzzle(time.Now())
+	if err != nil {
+		dialog.ShowError(err, ui.window)
+		return
+	}
+	ui.loadEntry(entry)
+}
+
+func (ui *puzzleUI) loadEntry(entry PuzzleEntry) {
+	state, err := entry.State()
+	if err != nil {
+		dialog.ShowError(err, ui.window)
+		return
+	}
+	ui.stopAnimation()
+	if state.Size() != ui.boardSize {
+		ui.boardSize = state.Size()
+		ui.rebuildGrid(ui.boardSize)
+		ui.sizeSelect.SetSelected(boardSizeLabel(ui.boardSize))
+	}
+	ui.currentState = state
+	ui.currentEntryID = entry.ID
+	ui.currentOptimal = entry.OptimalMoves
+	ui.attemptStart = time.Now()
+	ui.solutionPath = nil
+	ui.stepIndex = 0
+	ui.manualMoveCount = 0
+	ui.Paint(state)
+	ui.refreshStats()
+	ui.SetStatus(fmt.Sprintf("Puzzle %s cargado • óptimo: %d pasos", entry.ID, entry.OptimalMoves))
+}
+
+// recordIfEntry anota un intento cuando el tablero activo proviene de la biblioteca.
+func (ui *puzzleUI) recordIfEntry(movesUsed int, usedHint bool) {
+	if ui.currentEntryID == "" {
+		return
+	}
+	ui.progress.RecordAttempt(ui.currentEntryID, movesUsed, ui.currentOptimal, !usedHint, time.Since(ui.attemptStart))
+	ui.saveProgress()
+	ui.refreshStats()
+}
+
+func (ui *puzzleUI) refreshStats() {
+	stats := ui.progress.Stats[ui.currentEntryID]
+	ui.statsLabel.SetText(fmt.Sprintf("Intentos: %d • Mejor tiempo: %.0fs • Promedio/óptimo: %.2f",
+		stats.Attempts, stats.BestTimeToSolve, stats.AvgMovesVsOptimal))
+}
+
+func (ui *puzzleUI) saveLibrary() {
+	if ui.libraryPath == "" {
+		return
+	}
+	_ = SaveLibrary(ui.libraryPath, ui.library)
+}
+
+func (ui *puzzleUI) saveProgress() {
+	if ui.progressPath == "" {
+		return
+	}
+	_ = SaveProgress(ui.progressPath, ui.progress)
+}
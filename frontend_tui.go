@@ -0,0 +1,273 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/gdamore/tcell/v2"
+)
+
+// tuiFrontend dibuja el tablero en la terminal con caracteres de caja y
+// traduce flechas/WASD a movimientos manuales de una ficha, más atajos para
+// mezclar, resolver y avanzar paso a paso. No depende de Fyne, así que
+// Puzzle/IDAStar corren en scripts o CI sin abrir ventana.
+type tuiFrontend struct {
+	screen       tcell.Screen
+	boardSize    int
+	currentState State
+	solutionPath []State
+	stepIndex    int
+	heuristic    Heuristic
+	algorithm    Algorithm
+	status       string
+}
+
+func newTUIFrontend() (*tuiFrontend, error) {
+	screen, err := tcell.NewScreen()
+	if err != nil {
+		return nil, err
+	}
+	if err := screen.Init(); err != nil {
+		return nil, err
+	}
+	return &tuiFrontend{
+		screen:       screen,
+		boardSize:    DefaultGridSize,
+		currentState: Goal(DefaultGridSize),
+		heuristic:    heuristicManhattan,
+		algorithm:    algorithmAStar,
+		status:       statusReadyMessage,
+	}, nil
+}
+
+func (t *tuiFrontend) SetStatus(msg string) {
+	t.status = msg
+	t.draw()
+}
+
+func (t *tuiFrontend) Paint(s State) {
+	t.currentState = s
+	t.draw()
+}
+
+func (t *tuiFrontend) Run() error {
+	defer t.screen.Fini()
+	t.draw()
+	for {
+		switch ev := t.screen.PollEvent().(type) {
+		case *tcell.EventResize:
+			t.screen.Sync()
+		case *tcell.EventKey:
+			action, quit := keyToAction(ev)
+			if action == ActionNone {
+				continue
+			}
+			if quit {
+				return nil
+			}
+			t.handle(action)
+		}
+	}
+}
+
+func keyToAction(ev *tcell.EventKey) (action Action, quit bool) {
+	switch ev.Key() {
+	case tcell.KeyUp:
+		return ActionMoveUp, false
+	case tcell.KeyDown:
+		return ActionMoveDown, false
+	case tcell.KeyLeft:
+		return ActionMoveLeft, false
+	case tcell.KeyRight:
+		return ActionMoveRight, false
+	case tcell.KeyEscape, tcell.KeyCtrlC:
+		return ActionQuit, true
+	}
+	switch ev.Rune() {
+	case 'w', 'W':
+		return ActionMoveUp, false
+	case 's', 'S':
+		return ActionMoveDown, false
+	case 'a', 'A':
+		return ActionMoveLeft, false
+	case 'd', 'D':
+		return ActionMoveRight, false
+	case 'm', 'M':
+		return ActionShuffle, false
+	case 'o', 'O':
+		return ActionSolve, false
+	case 'n', 'N':
+		return ActionStep, false
+	case 'r', 'R':
+		return ActionReset, false
+	case 'q', 'Q':
+		return ActionQuit, true
+	}
+	return ActionNone, false
+}
+
+func (t *tuiFrontend) handle(a Action) {
+	switch a {
+	case ActionMoveUp, ActionMoveDown, ActionMoveLeft, ActionMoveRight:
+		t.manualMove(a)
+	case ActionReset:
+		t.currentState = Goal(t.boardSize)
+		t.solutionPath = nil
+		t.stepIndex = 0
+		t.status = statusResetMessage
+	case ActionShuffle:
+		state, err := ShuffleFromGoal(t.boardSize, defaultShuffleSteps)
+		if err != nil {
+			t.status = err.Error()
+			break
+		}
+		t.currentState = state
+		t.solutionPath = nil
+		t.stepIndex = 0
+		t.status = fmt.Sprintf(msgMixedFmt, defaultShuffleSteps)
+	case ActionSolve, ActionStep:
+		t.solveOrStep(a)
+	}
+	t.draw()
+}
+
+// manualMove interpreta la flecha/tecla como la dirección en la que se mueve
+// el hueco; si el destino cae fuera del tablero, el movimiento se ignora.
+func (t *tuiFrontend) manualMove(a Action) {
+	n := t.currentState.Size()
+	blank := t.currentState.blankIndex()
+	row, col := blank/n, blank%n
+
+	targetRow, targetCol := row, col
+	switch a {
+	case ActionMoveUp:
+		targetRow--
+	case ActionMoveDown:
+		targetRow++
+	case ActionMoveLeft:
+		targetCol--
+	case ActionMoveRight:
+		targetCol++
+	}
+	if targetRow < 0 || targetRow >= n || targetCol < 0 || targetCol >= n {
+		t.status = "Movimiento inválido."
+		return
+	}
+	targetIdx := targetRow*n + targetCol
+	for _, nb := range t.currentState.Neighbors() {
+		if nb.blankIndex() == targetIdx {
+			t.currentState = nb
+			t.solutionPath = nil
+			t.stepIndex = 0
+			t.status = statusReadyMessage
+			return
+		}
+	}
+}
+
+func (t *tuiFrontend) solveOrStep(a Action) {
+	if len(t.solutionPath) == 0 {
+		var result SearchResult
+		var err error
+		if t.algorithm == algorithmIDAStar {
+			result, err = IDAStar(t.currentState, t.heuristic)
+		} else {
+			result, err = Puzzle(t.currentState, t.heuristic, defaultMaxExpand)
+		}
+		if err != nil {
+			t.status = err.Error()
+			return
+		}
+		t.solutionPath = result.path
+		t.stepIndex = 0
+		if a == ActionSolve {
+			t.currentState = result.path[len(result.path)-1]
+			t.stepIndex = len(result.path)
+			t.status = fmt.Sprintf(msgSolvedFmt, len(result.path)-1, result.expanded)
+			return
+		}
+	}
+	if a == ActionSolve {
+		t.currentState = t.solutionPath[len(t.solutionPath)-1]
+		t.stepIndex = len(t.solutionPath)
+		t.status = fmt.Sprintf(msgSolvedFmt, len(t.solutionPath)-1, 0)
+		return
+	}
+	if t.stepIndex >= len(t.solutionPath) {
+		t.status = statusAlreadyFinal
+		return
+	}
+	t.currentState = t.solutionPath[t.stepIndex]
+	t.status = fmt.Sprintf(msgStepFmt, t.stepIndex, len(t.solutionPath)-1)
+	t.stepIndex++
+}
+
+const tuiCellWidth = 5
+
+// draw redibuja el tablero n×n con caracteres de caja más la línea de estado.
+func (t *tuiFrontend) draw() {
+	t.screen.Clear()
+	n := t.currentState.Size()
+
+	for r := 0; r <= n; r++ {
+		for c := 0; c <= n; c++ {
+			x, y := c*tuiCellWidth, r*2
+			t.screen.SetContent(x, y, borderRune(r, c, n), nil, tcell.StyleDefault)
+			if c < n {
+				for i := 1; i < tuiCellWidth; i++ {
+					t.screen.SetContent(x+i, y, '─', nil, tcell.StyleDefault)
+				}
+			}
+		}
+		if r >= n {
+			continue
+		}
+		for c := 0; c < n; c++ {
+			x := c * tuiCellWidth
+			t.screen.SetContent(x, r*2+1, '│', nil, tcell.StyleDefault)
+			label := "  "
+			v := t.currentState.At(r*n + c)
+			if v != BlankTile {
+				label = fmt.Sprintf("%2d", v)
+			}
+			for i, ch := range label {
+				t.screen.SetContent(x+1+i, r*2+1, ch, nil, tcell.StyleDefault)
+			}
+		}
+		t.screen.SetContent(n*tuiCellWidth, r*2+1, '│', nil, tcell.StyleDefault)
+	}
+
+	statusY := (n+1)*2 + 1
+	drawLine(t.screen, 0, statusY, t.status)
+	drawLine(t.screen, 0, statusY+1, "flechas/WASD: mover · m: mezclar · o: resolver · n: paso · r: reiniciar · q: salir")
+
+	t.screen.Show()
+}
+
+func borderRune(r, c, n int) rune {
+	switch {
+	case r == 0 && c == 0:
+		return '┌'
+	case r == 0 && c == n:
+		return '┐'
+	case r == n && c == 0:
+		return '└'
+	case r == n && c == n:
+		return '┘'
+	case r == 0:
+		return '┬'
+	case r == n:
+		return '┴'
+	case c == 0:
+		return '├'
+	case c == n:
+		return '┤'
+	default:
+		return '┼'
+	}
+}
+
+func drawLine(screen tcell.Screen, x, y int, s string) {
+	for i, ch := range s {
+		screen.SetContent(x+i, y, ch, nil, tcell.StyleDefault)
+	}
+}
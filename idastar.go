@@ -0,0 +1,90 @@
+package main
+
+import "math"
+
+// Algorithm selecciona la estrategia de búsqueda usada para resolver el tablero.
+type Algorithm int
+
+const (
+	algorithmAStar Algorithm = iota
+	algorithmIDAStar
+)
+
+var algorithmDisplayName = map[Algorithm]string{
+	algorithmAStar:   "A*",
+	algorithmIDAStar: "IDA*",
+}
+
+// IDAStar resuelve el tablero por profundización iterativa sobre f = g + h. A
+// diferencia de Puzzle, no guarda cameFrom ni un heap: la pila de recursión
+// reconstruye la ruta y la memoria usada es O(profundidad), lo que la hace
+// viable para tableros grandes (15-, 24-, 35-puzzle) donde A* con Manhattan
+// puede llegar a expandir millones de nodos.
+func IDAStar(start State, kind Heuristic) (SearchResult, error) {
+	if !IsSolvable(start) {
+		return SearchResult{}, errUnsolvable
+	}
+
+	goal := Goal(start.Size())
+	bound, err := heuristicCost(start, kind)
+	if err != nil {
+		return SearchResult{}, err
+	}
+
+	path := []State{start}
+	expanded := 0
+
+	for {
+		t, found := idaSearch(&path, 0, bound, kind, goal, &expanded)
+		if found {
+			result := make([]State, len(path))
+			copy(result, path)
+			return SearchResult{path: result, expanded: expanded, found: true}, nil
+		}
+		if t == math.MaxInt {
+			return SearchResult{expanded: expanded}, errNoSolution
+		}
+		bound = t
+	}
+}
+
+// idaSearch hace la DFS acotada por bound y devuelve, cuando no encuentra la
+// meta, el menor f que la excedió (el bound de la siguiente iteración).
+func idaSearch(path *[]State, g, bound int, kind Heuristic, goal State, expanded *int) (int, bool) {
+	current := (*path)[len(*path)-1]
+	h, err := heuristicCost(current, kind)
+	if err != nil {
+		return math.MaxInt, false
+	}
+	f := g + h
+	if f > bound {
+		return f, false
+	}
+	if current.Equal(goal) {
+		return f, true
+	}
+
+	*expanded++
+	min := math.MaxInt
+	hasLast := len(*path) >= 2
+	var last State
+	if hasLast {
+		last = (*path)[len(*path)-2]
+	}
+
+	for _, nb := range current.Neighbors() {
+		if hasLast && nb.Equal(last) {
+			continue // no deshacer el movimiento anterior
+		}
+		*path = append(*path, nb)
+		t, found := idaSearch(path, g+1, bound, kind, goal, expanded)
+		if found {
+			return t, true
+		}
+		if t < min {
+			min = t
+		}
+		*path = (*path)[:len(*path)-1]
+	}
+	return min, false
+}
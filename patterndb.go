@@ -0,0 +1,178 @@
+package main
+
+import (
+	"encoding/gob"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+)
+
+// Los grupos disjuntos del 8-puzzle: cada ficha pertenece a un único grupo y
+// su costo se suma al del otro grupo (heurística aditiva admisible).
+var (
+	patternGroupA = []int{1, 2, 3, 4}
+	patternGroupB = []int{5, 6, 7, 8}
+)
+
+// PatternDB guarda, para cada grupo disjunto, el mínimo número de movimientos
+// de fichas del grupo necesarios para llevar sus fichas (+ el hueco) a la
+// posición meta, indexado
+// por una codificación compacta de esas posiciones.
+type PatternDB struct {
+	n      int
+	groupA map[uint64]uint8
+	groupB map[uint64]uint8
+}
+
+type patternDBCacheEntry struct {
+	db  *PatternDB
+	err error
+}
+
+var (
+	patternDBCacheMu sync.Mutex
+	patternDBCache   = map[int]*patternDBCacheEntry{}
+)
+
+// getPatternDB construye (o carga desde disco) la base de patrones la primera
+// vez que una búsqueda la pide para cada lado n y la reutiliza después; no se
+// precalcula al arrancar el programa. El tablero es seleccionable en tiempo de
+// ejecución, así que la caché se indexa por n en vez de usar un único
+// sync.Once global.
+func getPatternDB(n int) (*PatternDB, error) {
+	patternDBCacheMu.Lock()
+	defer patternDBCacheMu.Unlock()
+	if entry, ok := patternDBCache[n]; ok {
+		return entry.db, entry.err
+	}
+	db, err := loadOrBuildPatternDB(n)
+	patternDBCache[n] = &patternDBCacheEntry{db: db, err: err}
+	return db, err
+}
+
+func buildPatternDB(n int) (*PatternDB, error) {
+	if n != 3 {
+		return nil, fmt.Errorf("pattern database is only available for the 8-puzzle (3x3)")
+	}
+	return &PatternDB{
+		n:      n,
+		groupA: bfsGroupDistances(n, patternGroupA),
+		groupB: bfsGroupDistances(n, patternGroupB),
+	}, nil
+}
+
+// bfsGroupDistances hace una búsqueda hacia atrás desde la meta sobre el
+// espacio completo de estados, pero indexa lo visitado por la proyección del
+// grupo (posiciones de sus fichas + el hueco). Solo cuentan los movimientos de
+// fichas del grupo (mover otra ficha cuesta 0), así que los costos de grupos
+// disjuntos se pueden sumar; una proyección se reexplora solo si mejora su costo.
+func bfsGroupDistances(n int, group []int) map[uint64]uint8 {
+	inGroup := make(map[int]bool, len(group))
+	for _, v := range group {
+		inGroup[v] = true
+	}
+	start := Goal(n)
+	dist := map[uint64]uint8{encodeGroup(start, group): 0}
+	queue := []State{start}
+
+	for len(queue) > 0 {
+		cur := queue[0]
+		queue = queue[1:]
+		d := dist[encodeGroup(cur, group)]
+
+		for _, nb := range cur.Neighbors() {
+			nd := d
+			if inGroup[cur.tiles[nb.blankIndex()]] {
+				nd++
+			}
+			key := encodeGroup(nb, group)
+			if old, seen := dist[key]; seen && old <= nd {
+				continue
+			}
+			dist[key] = nd
+			queue = append(queue, nb)
+		}
+	}
+	return dist
+}
+
+// encodeGroup empaqueta, en base n², las posiciones de las fichas del grupo
+// seguidas de la posición del hueco.
+func encodeGroup(s State, group []int) uint64 {
+	base := uint64(s.n * s.n)
+	var key, mul uint64 = 0, 1
+	for _, tileVal := range group {
+		key += uint64(indexOfValue(s, tileVal)) * mul
+		mul *= base
+	}
+	key += uint64(s.blankIndex()) * mul
+	return key
+}
+
+func indexOfValue(s State, v int) int {
+	for i, t := range s.tiles {
+		if t == v {
+			return i
+		}
+	}
+	return -1
+}
+
+// Cost suma el costo de cada grupo disjunto para el estado recibido.
+func (pdb *PatternDB) Cost(s State) (int, error) {
+	if s.n != pdb.n {
+		return 0, fmt.Errorf("pattern database was built for side %d, not %d", pdb.n, s.n)
+	}
+	da, ok := pdb.groupA[encodeGroup(s, patternGroupA)]
+	if !ok {
+		return 0, errors.New("state outside pattern database (group A)")
+	}
+	db, ok := pdb.groupB[encodeGroup(s, patternGroupB)]
+	if !ok {
+		return 0, errors.New("state outside pattern database (group B)")
+	}
+	return int(da) + int(db), nil
+}
+
+type patternDBFile struct {
+	GroupA map[uint64]uint8
+	GroupB map[uint64]uint8
+}
+
+func patternDBPath(n int) (string, error) {
+	dir, err := appConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, fmt.Sprintf("pattern_db_%d.gob", n)), nil
+}
+
+// loadOrBuildPatternDB intenta leer la caché en disco; si falta o está
+// corrupta, reconstruye con bfsGroupDistances y la vuelve a guardar.
+func loadOrBuildPatternDB(n int) (*PatternDB, error) {
+	path, pathErr := patternDBPath(n)
+	if pathErr == nil {
+		if f, err := os.Open(path); err == nil {
+			var pf patternDBFile
+			decErr := gob.NewDecoder(f).Decode(&pf)
+			f.Close()
+			if decErr == nil {
+				return &PatternDB{n: n, groupA: pf.GroupA, groupB: pf.GroupB}, nil
+			}
+		}
+	}
+
+	pdb, err := buildPatternDB(n)
+	if err != nil {
+		return nil, err
+	}
+	if pathErr == nil {
+		if f, err := os.Create(path); err == nil {
+			_ = gob.NewEncoder(f).Encode(patternDBFile{GroupA: pdb.groupA, GroupB: pdb.groupB})
+			f.Close()
+		}
+	}
+	return pdb, nil
+}
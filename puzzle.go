@@ -11,16 +11,19 @@ import (
 )
 
 const (
-	gridSize         = 3
-	boardLen         = gridSize * gridSize // 9
+	defaultGridSize  = 3
 	blankTile        = 0
 	defaultMaxExpand = 0 // 0 = sin límite para A*
+	minGridSize      = 2
+	maxGridSize      = 6
 )
 
 var (
 	errInvalidSteps     = errors.New("steps must be >= 0")
 	errNoSolution       = errors.New("solution not found")
 	errUnknownHeuristic = errors.New("unknown heuristic")
+	errUnsolvable       = errors.New("board is not solvable")
+	errInvalidSize      = fmt.Errorf("board side must be between %d and %d", minGridSize, maxGridSize)
 )
 
 type Heuristic int
@@ -28,46 +31,98 @@ type Heuristic int
 const (
 	heuristicManhattan Heuristic = iota
 	heuristicMisplaced
+	heuristicPatternDB
 )
 
 var heuristicDisplayName = map[Heuristic]string{
 	heuristicManhattan: "Manhattan",
 	heuristicMisplaced: "Misplaced",
+	heuristicPatternDB: "Pattern DB",
 }
 
-type State [boardLen]int
+// State representa un tablero n×n en orden de fila; blankTile marca el hueco.
+// El lado n se infiere de len(tiles), así un mismo tipo sirve para el 8-, 15-,
+// 24- y 35-puzzle.
+type State struct {
+	tiles []int
+	n     int
+}
+
+// NewState construye un State a partir de una lista plana de longitud n².
+func NewState(tiles []int) (State, error) {
+	n := int(math.Sqrt(float64(len(tiles))))
+	if n*n != len(tiles) || n < minGridSize || n > maxGridSize {
+		return State{}, errInvalidSize
+	}
+	cp := make([]int, len(tiles))
+	copy(cp, tiles)
+	return State{tiles: cp, n: n}, nil
+}
+
+// Goal construye el estado final para un tablero de lado n: 1..n²-1 y el hueco al final.
+func Goal(n int) State {
+	tiles := make([]int, n*n)
+	for i := range tiles {
+		tiles[i] = i + 1
+	}
+	tiles[len(tiles)-1] = blankTile
+	return State{tiles: tiles, n: n}
+}
+
+// Size devuelve el lado n del tablero.
+func (s State) Size() int { return s.n }
+
+// At devuelve el valor de la celda i (orden de fila).
+func (s State) At(i int) int { return s.tiles[i] }
 
-func Goal() State { return State{1, 2, 3, 4, 5, 6, 7, 8, blankTile} }
+// Equal compara dos estados por valor.
+func (s State) Equal(other State) bool {
+	if s.n != other.n || len(s.tiles) != len(other.tiles) {
+		return false
+	}
+	for i, v := range s.tiles {
+		if other.tiles[i] != v {
+			return false
+		}
+	}
+	return true
+}
 
-// String serializa el estado (útil como clave)
+// String serializa el estado (útil como clave de mapa)
 func (s State) String() string {
 	var b strings.Builder
-	for i, v := range s {
+	fmt.Fprintf(&b, "%d:", s.n)
+	for i, v := range s.tiles {
 		if v == blankTile {
 			b.WriteString("_")
 		} else {
-			b.WriteString(fmt.Sprintf("%d", v))
+			fmt.Fprintf(&b, "%d", v)
 		}
-		if i%gridSize == gridSize-1 && i != boardLen-1 {
-			b.WriteString("|")
-		} else if i != boardLen-1 {
-			b.WriteString(",")
+		if i != len(s.tiles)-1 {
+			if i%s.n == s.n-1 {
+				b.WriteString("|")
+			} else {
+				b.WriteString(",")
+			}
 		}
 	}
 	return b.String()
 }
 
-// Neighbors genera estados vecinos moviendo el espacio vacío
-func (s State) Neighbors() []State {
-	zeroIndex := 0
-	for i := 0; i < boardLen; i++ {
-		if s[i] == blankTile {
-			zeroIndex = i
-			break
+func (s State) blankIndex() int {
+	for i, v := range s.tiles {
+		if v == blankTile {
+			return i
 		}
 	}
-	row := zeroIndex / gridSize
-	col := zeroIndex % gridSize
+	return -1
+}
+
+// Neighbors genera estados vecinos moviendo el espacio vacío
+func (s State) Neighbors() []State {
+	zeroIndex := s.blankIndex()
+	row := zeroIndex / s.n
+	col := zeroIndex % s.n
 
 	type delta struct{ dr, dc int }
 	allowedMoves := [...]delta{
@@ -81,24 +136,51 @@ func (s State) Neighbors() []State {
 	for _, mv := range allowedMoves {
 		newRow := row + mv.dr
 		newCol := col + mv.dc
-		if newRow < 0 || newRow >= gridSize || newCol < 0 || newCol >= gridSize {
+		if newRow < 0 || newRow >= s.n || newCol < 0 || newCol >= s.n {
 			continue
 		}
-		newIndex := newRow*gridSize + newCol
-		next := s
-		next[zeroIndex], next[newIndex] = next[newIndex], next[zeroIndex]
-		out = append(out, next)
+		newIndex := newRow*s.n + newCol
+		nextTiles := make([]int, len(s.tiles))
+		copy(nextTiles, s.tiles)
+		nextTiles[zeroIndex], nextTiles[newIndex] = nextTiles[newIndex], nextTiles[zeroIndex]
+		out = append(out, State{tiles: nextTiles, n: s.n})
 	}
 	return out
 }
 
+// IsSolvable aplica la regla de paridad de inversiones: con lado impar el
+// tablero es resoluble si el número de inversiones es par; con lado par hay
+// que sumarle la fila del hueco contada desde abajo y exigir que el total sea
+// impar. Se evalúa antes de lanzar A*/IDA* para no agotar memoria en vano.
+func IsSolvable(s State) bool {
+	inversions := 0
+	for i := 0; i < len(s.tiles); i++ {
+		if s.tiles[i] == blankTile {
+			continue
+		}
+		for j := i + 1; j < len(s.tiles); j++ {
+			if s.tiles[j] == blankTile {
+				continue
+			}
+			if s.tiles[i] > s.tiles[j] {
+				inversions++
+			}
+		}
+	}
+	if s.n%2 == 1 {
+		return inversions%2 == 0
+	}
+	blankRowFromBottom := s.n - s.blankIndex()/s.n
+	return (inversions+blankRowFromBottom)%2 == 1
+}
+
 // Heurísticas
 
 func heuristicCost(s State, kind Heuristic) (int, error) {
 	switch kind {
 	case heuristicMisplaced:
 		count := 0
-		for i, v := range s {
+		for i, v := range s.tiles {
 			if v == blankTile {
 				continue
 			}
@@ -109,16 +191,22 @@ func heuristicCost(s State, kind Heuristic) (int, error) {
 		return count, nil
 	case heuristicManhattan:
 		sum := 0
-		for i, v := range s {
+		for i, v := range s.tiles {
 			if v == blankTile {
 				continue
 			}
 			target := v - 1
-			x1, y1 := i%gridSize, i/gridSize
-			x2, y2 := target%gridSize, target/gridSize
+			x1, y1 := i%s.n, i/s.n
+			x2, y2 := target%s.n, target/s.n
 			sum += int(math.Abs(float64(x1-x2)) + math.Abs(float64(y1-y2)))
 		}
 		return sum, nil
+	case heuristicPatternDB:
+		pdb, err := getPatternDB(s.n)
+		if err != nil {
+			return 0, err
+		}
+		return pdb.Cost(s)
 	default:
 		return 0, errUnknownHeuristic
 	}
@@ -163,6 +251,10 @@ type SearchResult struct {
 
 // maxExpand = 0 ⇒ sin límite.
 func Puzzle(start State, kind Heuristic, maxExpand int) (SearchResult, error) {
+	if !IsSolvable(start) {
+		return SearchResult{}, errUnsolvable
+	}
+
 	open := &minQueue{}
 	heap.Init(open)
 
@@ -173,6 +265,7 @@ func Puzzle(start State, kind Heuristic, maxExpand int) (SearchResult, error) {
 	startNode := &node{state: start, g: 0, h: h0}
 	heap.Push(open, startNode)
 
+	goal := Goal(start.n)
 	cameFrom := map[string]*node{start.String(): startNode}
 	closed := map[string]bool{}
 	expanded := 0
@@ -180,7 +273,7 @@ func Puzzle(start State, kind Heuristic, maxExpand int) (SearchResult, error) {
 	for open.Len() > 0 {
 		current := heap.Pop(open).(*node)
 
-		if current.state == Goal() {
+		if current.state.Equal(goal) {
 			// reconstruir ruta
 			reversed := make([]State, 0, current.g+1)
 			for n := current; n != nil; n = n.parent {
@@ -221,15 +314,19 @@ func Puzzle(start State, kind Heuristic, maxExpand int) (SearchResult, error) {
 	return SearchResult{found: false, expanded: expanded}, errNoSolution
 }
 
-// ShuffleFromGoal desordena con un “random walk” de 'steps' movimientos válidos
-func ShuffleFromGoal(steps int) (State, error) {
+// ShuffleFromGoal desordena con un "random walk" de 'steps' movimientos válidos
+// a partir del estado final de un tablero de lado n.
+func ShuffleFromGoal(n, steps int) (State, error) {
 	if steps < 0 {
 		return State{}, errInvalidSteps
 	}
+	if n < minGridSize || n > maxGridSize {
+		return State{}, errInvalidSize
+	}
 	seed := time.Now().UnixNano()
 	rng := rand.New(rand.NewSource(seed))
 
-	state := Goal()
+	state := Goal(n)
 	for i := 0; i < steps; i++ {
 		neighbors := state.Neighbors()
 		state = neighbors[rng.Intn(len(neighbors))]
@@ -238,7 +335,8 @@ func ShuffleFromGoal(steps int) (State, error) {
 }
 
 const (
-	GridSize  = gridSize
-	BoardLen  = boardLen
-	BlankTile = blankTile
+	DefaultGridSize = defaultGridSize
+	MinGridSize     = minGridSize
+	MaxGridSize     = maxGridSize
+	BlankTile       = blankTile
 )
@@ -0,0 +1,240 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/rand"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+const appConfigDirName = "puzzle8"
+
+// appConfigDir devuelve (creándolo si hace falta) el directorio de
+// configuración del usuario para esta app, p. ej. ~/.config/puzzle8.
+func appConfigDir() (string, error) {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "", err
+	}
+	full := filepath.Join(dir, appConfigDirName)
+	if err := os.MkdirAll(full, 0o755); err != nil {
+		return "", err
+	}
+	return full, nil
+}
+
+// Difficulty clasifica una entrada de la biblioteca según su longitud óptima de solución.
+type Difficulty int
+
+const (
+	DifficultyEasy Difficulty = iota
+	DifficultyMedium
+	DifficultyHard
+	DifficultyExpert
+)
+
+var difficultyDisplayName = map[Difficulty]string{
+	DifficultyEasy:   "Fácil",
+	DifficultyMedium: "Media",
+	DifficultyHard:   "Difícil",
+	DifficultyExpert: "Experta",
+}
+
+// classifyDifficulty asigna el bucket según el número óptimo de movimientos:
+// Fácil ≤12, Media 13-20, Difícil 21-27, Experta 28+.
+func classifyDifficulty(optimalMoves int) Difficulty {
+	switch {
+	case optimalMoves <= 12:
+		return DifficultyEasy
+	case optimalMoves <= 20:
+		return DifficultyMedium
+	case optimalMoves <= 27:
+		return DifficultyHard
+	default:
+		return DifficultyExpert
+	}
+}
+
+// shuffleStepsForBucket aproxima cuántos pasos de random walk suelen producir
+// tableros de la dificultad pedida; Generate igual reclasifica por el óptimo real.
+func shuffleStepsForBucket(d Difficulty) int {
+	switch d {
+	case DifficultyEasy:
+		return 10
+	case DifficultyMedium:
+		return 18
+	case DifficultyHard:
+		return 25
+	default:
+		return 40
+	}
+}
+
+// PuzzleEntry es una posición de la biblioteca junto con su solución óptima.
+type PuzzleEntry struct {
+	ID           string     `json:"id"`
+	BoardSize    int        `json:"boardSize"`
+	Tiles        []int      `json:"tiles"`
+	OptimalMoves int        `json:"optimalMoves"`
+	Difficulty   Difficulty `json:"difficulty"`
+}
+
+// State reconstruye el State jugable a partir de los tiles persistidos.
+func (e PuzzleEntry) State() (State, error) { return NewState(e.Tiles) }
+
+// Library es la colección persistida de PuzzleEntry.
+type Library struct {
+	Entries []PuzzleEntry `json:"entries"`
+}
+
+var errNoMoreCandidates = errors.New("no more boards found for this difficulty")
+
+// LoadLibrary lee la biblioteca desde disco; si el archivo no existe devuelve una vacía.
+func LoadLibrary(path string) (*Library, error) {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return &Library{}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var lib Library
+	if err := json.Unmarshal(data, &lib); err != nil {
+		return nil, err
+	}
+	return &lib, nil
+}
+
+// SaveLibrary persiste la biblioteca en un JSON legible.
+func SaveLibrary(path string, lib *Library) error {
+	data, err := json.MarshalIndent(lib, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0o644)
+}
+
+// ByDifficulty filtra las entradas por bucket.
+func (lib *Library) ByDifficulty(d Difficulty) []PuzzleEntry {
+	out := make([]PuzzleEntry, 0)
+	for _, e := range lib.Entries {
+		if e.Difficulty == d {
+			out = append(out, e)
+		}
+	}
+	return out
+}
+
+// Generate mezcla desde la meta y resuelve con A* hasta sumar n tableros
+// nuevos cuya longitud óptima caiga en bucket, añadiéndolos a la biblioteca.
+func (lib *Library) Generate(boardSize, n int, bucket Difficulty, kind Heuristic) error {
+	added := 0
+	attempts := 0
+	maxAttempts := n * 50
+	for added < n && attempts < maxAttempts {
+		attempts++
+		steps := shuffleStepsForBucket(bucket)
+		state, err := ShuffleFromGoal(boardSize, steps)
+		if err != nil {
+			return err
+		}
+		result, err := Puzzle(state, kind, defaultMaxExpand)
+		if err != nil || !result.found {
+			continue
+		}
+		optimal := len(result.path) - 1
+		if classifyDifficulty(optimal) != bucket {
+			continue
+		}
+		entry := PuzzleEntry{
+			ID:           fmt.Sprintf("%d-%d-%d", boardSize, optimal, len(lib.Entries)),
+			BoardSize:    boardSize,
+			Tiles:        append([]int(nil), state.tiles...),
+			OptimalMoves: optimal,
+			Difficulty:   bucket,
+		}
+		lib.Entries = append(lib.Entries, entry)
+		added++
+	}
+	if added < n {
+		return fmt.Errorf("%w: generated %d of %d", errNoMoreCandidates, added, n)
+	}
+	return nil
+}
+
+// DailyPuzzle elige una entrada de forma determinística a partir de la fecha:
+// un mismo usuario obtiene el mismo tablero ese día mientras su biblioteca no
+// cambie (Generate puede añadir entradas y cambiar la elección). La biblioteca es
+// local y se genera con mezclas no deterministas, así que esto NO garantiza
+// el mismo tablero entre usuarios distintos (cada biblioteca tiene su propio
+// conjunto de entradas).
+func (lib *Library) DailyPuzzle(date time.Time) (PuzzleEntry, error) {
+	if len(lib.Entries) == 0 {
+		return PuzzleEntry{}, errors.New("library is empty")
+	}
+	seed := int64(date.Year())*10000 + int64(date.Month())*100 + int64(date.Day())
+	rng := rand.New(rand.NewSource(seed))
+	return lib.Entries[rng.Intn(len(lib.Entries))], nil
+}
+
+// UserStats acumula el progreso de un usuario en una entrada de la biblioteca.
+type UserStats struct {
+	Attempts           int     `json:"attempts"`
+	SolvedWithoutHints bool    `json:"solvedWithoutHints"`
+	AvgMovesVsOptimal  float64 `json:"avgMovesVsOptimal"`
+	BestTimeToSolve    float64 `json:"bestTimeToSolveSeconds"`
+}
+
+// Progress es el registro persistido de UserStats por id de puzzle.
+type Progress struct {
+	Stats map[string]UserStats `json:"stats"`
+}
+
+// LoadProgress lee el progreso desde disco; si el archivo no existe devuelve uno vacío.
+func LoadProgress(path string) (*Progress, error) {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return &Progress{Stats: map[string]UserStats{}}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var p Progress
+	if err := json.Unmarshal(data, &p); err != nil {
+		return nil, err
+	}
+	if p.Stats == nil {
+		p.Stats = map[string]UserStats{}
+	}
+	return &p, nil
+}
+
+// SaveProgress persiste el progreso en un JSON legible.
+func SaveProgress(path string, p *Progress) error {
+	data, err := json.MarshalIndent(p, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0o644)
+}
+
+// RecordAttempt actualiza las estadísticas de un puzzle tras un intento del usuario.
+func (p *Progress) RecordAttempt(id string, movesUsed, optimalMoves int, solvedWithoutHints bool, timeToSolve time.Duration) {
+	st := p.Stats[id]
+	prevTotal := st.AvgMovesVsOptimal * float64(st.Attempts)
+	st.Attempts++
+	if optimalMoves > 0 {
+		st.AvgMovesVsOptimal = (prevTotal + float64(movesUsed)/float64(optimalMoves)) / float64(st.Attempts)
+	}
+	if solvedWithoutHints {
+		st.SolvedWithoutHints = true
+	}
+	secs := timeToSolve.Seconds()
+	if st.BestTimeToSolve == 0 || secs < st.BestTimeToSolve {
+		st.BestTimeToSolve = secs
+	}
+	p.Stats[id] = st
+}